@@ -1,21 +1,99 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/JordanKoeller/relsim/server"
 )
 
 func main() {
-  // mux := http.NewServeMux()
- // mux.Handle("/static", http.FileServer(http.Dir("static")))
-  //mux.Handle("/obj", http.FileServer(http.Dir("obj")))
+	port := flag.Int("port", 3000, "port to serve on")
+	host := flag.String("host", "", "host/interface to bind to")
+	localOnly := flag.Bool("local-only", false, "bind to 127.0.0.1 regardless of -host")
+	web := flag.String("web", "dist", "path to the static frontend bundle")
+	mapDir := flag.String("map", "maps", "path to the lensing tile cache directory")
+	dev := flag.Bool("dev", false, "watch the dist/ bundle and live-reload connected browsers")
+	tlsCert := flag.String("tls-cert", "", "path to a TLS certificate; enables HTTPS with -tls-key")
+	tlsKey := flag.String("tls-key", "", "path to the TLS certificate's private key")
+	flag.Parse()
+
+	addr := *host
+	if *localOnly {
+		addr = "127.0.0.1"
+	}
+
+	broker := server.NewBroker()
+
+	router := server.NewRouter(*web)
+	router.Mount(server.NewSimulationService(nil))
+	router.Mount(server.NewStreamService(broker))
+	router.Mount(server.NewTileService(*mapDir, unrenderedTile))
+	router.Mount(server.NewMetaService())
 
-	s := http.Server{
-		Addr:    ":3000",
-		Handler: http.FileServer(http.Dir("dist")),
+	var handler http.Handler = router.Handler()
+	if *dev {
+		reloadBroker := server.NewReloadBroker()
+		router.Mount(server.NewLiveReloadService(reloadBroker))
+		if err := server.WatchDist(*web, reloadBroker); err != nil {
+			log.Fatalf("dev: watching %s: %v", *web, err)
+		}
+		handler = server.ReloadInjector(router.Handler())
+		log.Printf("dev: live-reload enabled for %s", *web)
 	}
 
-  log.Printf("Starting server on http://localhost%s", s.Addr)
-  log.Fatalf("Server Error: %v", s.ListenAndServe())
+	s := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", addr, *port),
+		Handler: handler,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		log.Print("shutting down")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			log.Printf("graceful shutdown failed: %v", err)
+		}
+	}()
+
+	displayHost := addr
+	if displayHost == "" {
+		displayHost = "localhost"
+	}
+
+	var err error
+	if *tlsCert != "" || *tlsKey != "" {
+		log.Printf("Starting server on https://%s:%d", displayHost, *port)
+		err = s.ListenAndServeTLS(*tlsCert, *tlsKey)
+	} else {
+		log.Printf("Starting server on http://%s:%d", displayHost, *port)
+		err = s.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server Error: %v", err)
+	}
+
+	<-shutdownDone
 }
 
+// unrenderedTile is a placeholder TileGenerator that fails every render,
+// so only tiles already cached under -map are served; any other tile
+// request gets a 500.
+func unrenderedTile(z, x, y int) ([]byte, error) {
+	return nil, fmt.Errorf("no rasterizer configured for tile %d/%d/%d", z, x, y)
+}
@@ -0,0 +1,64 @@
+package server
+
+import "sync"
+
+// Frame is a single rendered tile or image buffer produced by a running
+// simulation job, ready to be pushed to any subscribed viewer.
+type Frame struct {
+	JobID string
+	Data  []byte
+}
+
+// Broker fans frames for a job out to every client currently subscribed
+// to it, so multiple viewers can watch the same run progress.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[string]map[chan Frame]struct{}
+}
+
+// NewBroker returns an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string]map[chan Frame]struct{})}
+}
+
+// Subscribe registers a new listener for jobID's frames and returns the
+// channel it will receive them on. Call Unsubscribe when the listener is
+// done to release the channel.
+func (b *Broker) Subscribe(jobID string) chan Frame {
+	ch := make(chan Frame, 8)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs[jobID] == nil {
+		b.subs[jobID] = make(map[chan Frame]struct{})
+	}
+	b.subs[jobID][ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes ch from jobID's listener set and closes it.
+func (b *Broker) Unsubscribe(jobID string, ch chan Frame) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if subs, ok := b.subs[jobID]; ok {
+		delete(subs, ch)
+		if len(subs) == 0 {
+			delete(b.subs, jobID)
+		}
+	}
+	close(ch)
+}
+
+// Publish sends frame to every listener currently subscribed to its job.
+// A subscriber whose buffer is full misses this frame rather than
+// blocking the worker pool; it stays subscribed.
+func (b *Broker) Publish(frame Frame) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[frame.JobID] {
+		select {
+		case ch <- frame:
+		default:
+		}
+	}
+}
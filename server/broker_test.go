@@ -0,0 +1,80 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBrokerPublishFanOut(t *testing.T) {
+	b := NewBroker()
+	ch1 := b.Subscribe("job-1")
+	ch2 := b.Subscribe("job-1")
+	defer b.Unsubscribe("job-1", ch1)
+	defer b.Unsubscribe("job-1", ch2)
+
+	frame := Frame{JobID: "job-1", Data: []byte("frame")}
+	b.Publish(frame)
+
+	for _, ch := range []chan Frame{ch1, ch2} {
+		select {
+		case got := <-ch:
+			if got.JobID != frame.JobID || string(got.Data) != string(frame.Data) {
+				t.Fatalf("got frame %+v, want %+v", got, frame)
+			}
+		default:
+			t.Fatal("expected subscriber to receive published frame")
+		}
+	}
+}
+
+func TestBrokerPublishOnlyReachesItsJob(t *testing.T) {
+	b := NewBroker()
+	ch := b.Subscribe("job-1")
+	defer b.Unsubscribe("job-1", ch)
+
+	b.Publish(Frame{JobID: "job-2", Data: []byte("other")})
+
+	select {
+	case got := <-ch:
+		t.Fatalf("subscriber to job-1 should not receive job-2 frame, got %+v", got)
+	default:
+	}
+}
+
+func TestBrokerPublishDropsWhenSubscriberFull(t *testing.T) {
+	b := NewBroker()
+	ch := b.Subscribe("job-1")
+	defer b.Unsubscribe("job-1", ch)
+
+	// The subscriber channel has capacity 8; fill it and confirm a further
+	// publish is dropped instead of blocking the publisher.
+	for i := 0; i < 8; i++ {
+		b.Publish(Frame{JobID: "job-1", Data: []byte{byte(i)}})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(Frame{JobID: "job-1", Data: []byte("overflow")})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish should not block when a subscriber's channel is full")
+	}
+}
+
+func TestBrokerUnsubscribeClosesChannel(t *testing.T) {
+	b := NewBroker()
+	ch := b.Subscribe("job-1")
+	b.Unsubscribe("job-1", ch)
+
+	_, ok := <-ch
+	if ok {
+		t.Fatal("expected channel to be closed after Unsubscribe")
+	}
+
+	// Publishing after the only subscriber left should not panic even
+	// though the job's subscriber set has been cleaned up.
+	b.Publish(Frame{JobID: "job-1", Data: []byte("frame")})
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/websocket"
+)
+
+const reloadScript = `<script>
+(function() {
+	var ws = new WebSocket("ws://" + location.host + "/ws/reload");
+	ws.onmessage = function() { location.reload(); };
+})();
+</script>`
+
+// ReloadBroker broadcasts a "reload" notification to every browser tab
+// currently connected over /ws/reload.
+type ReloadBroker struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// NewReloadBroker returns an empty ReloadBroker.
+func NewReloadBroker() *ReloadBroker {
+	return &ReloadBroker{subs: make(map[chan struct{}]struct{})}
+}
+
+func (b *ReloadBroker) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *ReloadBroker) unsubscribe(ch chan struct{}) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+// Broadcast notifies every subscribed tab to reload.
+func (b *ReloadBroker) Broadcast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// LiveReloadService exposes /ws/reload, which pushes one message per
+// dist/ change so connected browser tabs can reload themselves. It
+// implements RoutedService.
+type LiveReloadService struct {
+	broker *ReloadBroker
+}
+
+// NewLiveReloadService returns a LiveReloadService backed by broker.
+func NewLiveReloadService(broker *ReloadBroker) *LiveReloadService {
+	return &LiveReloadService{broker: broker}
+}
+
+// AddRoutes registers the /ws/reload endpoint on mux.
+func (s *LiveReloadService) AddRoutes(mux *http.ServeMux) {
+	mux.Handle("/ws/reload", websocket.Handler(s.handleReload))
+}
+
+func (s *LiveReloadService) handleReload(ws *websocket.Conn) {
+	defer ws.Close()
+	ch := s.broker.subscribe()
+	defer s.broker.unsubscribe(ch)
+
+	for range ch {
+		if _, err := ws.Write([]byte("reload")); err != nil {
+			return
+		}
+	}
+}
+
+// WatchDist watches dir for changes and broadcasts a reload over broker
+// on every fsnotify event for an entry directly inside it; subdirectories
+// of dir are not watched. It runs
+// until the process exits; callers should only use it in -dev mode.
+func WatchDist(dir string, broker *ReloadBroker) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				log.Printf("dev: %s changed, reloading clients", event.Name)
+				broker.Broadcast()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("dev: watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// ReloadInjector wraps handler and inserts the live-reload client script
+// just before the last </body> of any HTML response it serves (a response
+// with no Content-Type counts as HTML). Responses without </body> are
+// served unchanged, and WebSocket upgrade requests are passed straight
+// to handler without buffering.
+func ReloadInjector(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &bufferedResponseWriter{ResponseWriter: w, buf: &bytes.Buffer{}, status: http.StatusOK}
+		handler.ServeHTTP(rec, r)
+
+		body := rec.buf.Bytes()
+		if isHTML(rec.Header()) {
+			if i := bytes.LastIndex(body, []byte("</body>")); i >= 0 {
+				var out bytes.Buffer
+				out.Write(body[:i])
+				out.WriteString(reloadScript)
+				out.Write(body[i:])
+				body = out.Bytes()
+			}
+		}
+
+		// The injected script changes the body length and invalidates any
+		// caching headers computed against the original body, so drop them
+		// before the real headers are written.
+		h := w.Header()
+		h.Del("Content-Length")
+		h.Del("ETag")
+		h.Del("Last-Modified")
+
+		w.WriteHeader(rec.status)
+		if _, err := w.Write(body); err != nil {
+			log.Printf("dev: writing injected response: %v", err)
+		}
+	})
+}
+
+func isHTML(h http.Header) bool {
+	ct := h.Get("Content-Type")
+	return ct == "" || strings.HasPrefix(ct, "text/html")
+}
+
+// bufferedResponseWriter captures a handler's response body and status
+// code so ReloadInjector can rewrite the body, and the headers that
+// depend on it, before anything reaches the client.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	buf    *bytes.Buffer
+	status int
+}
+
+func (w *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
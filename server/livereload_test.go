@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReloadInjectorInjectsBeforeBodyClose(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body><h1>hi</h1></body></html>"))
+	})
+
+	rec := httptest.NewRecorder()
+	ReloadInjector(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, reloadScript) {
+		t.Fatalf("expected injected reload script, got body: %s", body)
+	}
+	if i, j := strings.Index(body, reloadScript), strings.Index(body, "</body>"); i >= j {
+		t.Fatalf("expected reload script to be injected before </body>, got body: %s", body)
+	}
+}
+
+func TestReloadInjectorNoBodyCloseTagLeavesBodyUnchanged(t *testing.T) {
+	const original = "<html><body><h1>no closing tag"
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(original))
+	})
+
+	rec := httptest.NewRecorder()
+	ReloadInjector(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rec.Body.String(); got != original {
+		t.Fatalf("expected body unchanged without </body>, got %q, want %q", got, original)
+	}
+}
+
+func TestReloadInjectorSkipsNonHTMLContentType(t *testing.T) {
+	const original = `{"ok":true}`
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(original))
+	})
+
+	rec := httptest.NewRecorder()
+	ReloadInjector(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rec.Body.String(); got != original {
+		t.Fatalf("expected non-HTML body unchanged, got %q, want %q", got, original)
+	}
+}
+
+func TestReloadInjectorDropsCachingHeadersOnInjectedHTML(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Content-Length", "42")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Last-Modified", "yesterday")
+		w.Write([]byte("<html><body></body></html>"))
+	})
+
+	rec := httptest.NewRecorder()
+	ReloadInjector(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	for _, h := range []string{"Content-Length", "ETag", "Last-Modified"} {
+		if v := rec.Header().Get(h); v != "" {
+			t.Fatalf("expected header %s to be dropped after injection, got %q", h, v)
+		}
+	}
+}
+
+func TestReloadInjectorTreatsEmptyContentTypeAsHTML(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body></body></html>"))
+	})
+
+	rec := httptest.NewRecorder()
+	ReloadInjector(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !strings.Contains(rec.Body.String(), reloadScript) {
+		t.Fatalf("expected empty Content-Type to be treated as HTML, got body: %s", rec.Body.String())
+	}
+}
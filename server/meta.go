@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Version is the build version reported by /version. It is overridden at
+// build time via -ldflags "-X github.com/JordanKoeller/relsim/server.Version=...".
+var Version = "dev"
+
+// versionInfo is the JSON body returned by /version.
+type versionInfo struct {
+	Version string `json:"version"`
+}
+
+// MetaService exposes the operational endpoints a reverse proxy or
+// systemd unit needs: a liveness probe and build-version reporting. It
+// implements RoutedService.
+type MetaService struct{}
+
+// NewMetaService returns a MetaService.
+func NewMetaService() *MetaService {
+	return &MetaService{}
+}
+
+// AddRoutes registers /healthz and /version on mux.
+func (s *MetaService) AddRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/version", s.handleVersion)
+}
+
+func (s *MetaService) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *MetaService) handleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(versionInfo{Version: Version})
+}
@@ -0,0 +1,37 @@
+// Package server hosts the HTTP plumbing shared by relsim's subsystems: a
+// router that serves the static frontend bundle under "/" and lets
+// individual subsystems (simulation jobs, lensing maps, the geodesic
+// tracer, ...) mount their own API routes without touching main.
+package server
+
+import "net/http"
+
+// RoutedService is implemented by anything that wants to expose HTTP
+// endpoints on the shared router. Each service owns its own route prefix
+// and is responsible for registering it against the provided mux.
+type RoutedService interface {
+	AddRoutes(mux *http.ServeMux)
+}
+
+// Router wraps an http.ServeMux that serves the static asset root at "/"
+// and accumulates routes registered by the services mounted on it.
+type Router struct {
+	mux *http.ServeMux
+}
+
+// NewRouter builds a Router that serves staticDir at "/".
+func NewRouter(staticDir string) *Router {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(staticDir)))
+	return &Router{mux: mux}
+}
+
+// Mount registers svc's routes on the router.
+func (r *Router) Mount(svc RoutedService) {
+	svc.AddRoutes(r.mux)
+}
+
+// Handler returns the underlying http.Handler for use with an http.Server.
+func (r *Router) Handler() http.Handler {
+	return r.mux
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// JobStatus is the lifecycle state of a submitted simulation job.
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+// Job is a single simulation run (e.g. a relativistic ray trace) tracked
+// by the SimulationService.
+type Job struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+	Result []byte    `json:"-"`
+}
+
+// SimulationService exposes the JSON API the WebGL frontend uses to
+// submit simulation jobs, poll their status, and fetch binary results.
+// It implements RoutedService.
+type SimulationService struct {
+	mu     sync.RWMutex
+	jobs   map[string]*Job
+	nextID int64
+	assets map[string][]byte
+}
+
+// NewSimulationService returns a SimulationService with an empty job
+// table, serving the given named binary assets.
+func NewSimulationService(assets map[string][]byte) *SimulationService {
+	return &SimulationService{
+		jobs:   make(map[string]*Job),
+		assets: assets,
+	}
+}
+
+// AddRoutes registers the /api/v1/simulate, /api/v1/jobs/{id}, and
+// /api/v1/assets/{name} endpoints on mux.
+func (s *SimulationService) AddRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v1/simulate", s.handleSimulate)
+	mux.HandleFunc("/api/v1/jobs/", s.handleJob)
+	mux.HandleFunc("/api/v1/assets/", s.handleAsset)
+}
+
+func (s *SimulationService) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strconv.FormatInt(atomic.AddInt64(&s.nextID, 1), 10)
+	job := &Job{ID: id, Status: JobPending}
+
+	s.mu.Lock()
+	s.jobs[id] = job
+	s.mu.Unlock()
+
+	// TODO: hand job off to the simulation worker pool once it exists.
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+func (s *SimulationService) handleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/jobs/")
+	if id == "" {
+		http.Error(w, "missing job id", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	job, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("no such job %q", id), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+func (s *SimulationService) handleAsset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/assets/")
+	if name == "" {
+		http.Error(w, "missing asset name", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	data, ok := s.assets[name]
+	s.mu.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("no such asset %q", name), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(data)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandleSimulateCreatesJob(t *testing.T) {
+	s := NewSimulationService(nil)
+
+	rec := httptest.NewRecorder()
+	s.handleSimulate(rec, httptest.NewRequest(http.MethodPost, "/api/v1/simulate", nil))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	var job Job
+	if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if job.ID == "" {
+		t.Fatal("expected a non-empty job id")
+	}
+	if job.Status != JobPending {
+		t.Fatalf("job status = %q, want %q", job.Status, JobPending)
+	}
+
+	s.mu.RLock()
+	_, ok := s.jobs[job.ID]
+	s.mu.RUnlock()
+	if !ok {
+		t.Fatalf("job %q was not recorded in the job table", job.ID)
+	}
+}
+
+func TestHandleSimulateRejectsNonPost(t *testing.T) {
+	s := NewSimulationService(nil)
+
+	rec := httptest.NewRecorder()
+	s.handleSimulate(rec, httptest.NewRequest(http.MethodGet, "/api/v1/simulate", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleJob(t *testing.T) {
+	s := NewSimulationService(nil)
+	s.jobs["job-1"] = &Job{ID: "job-1", Status: JobDone}
+
+	t.Run("happy path", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleJob(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/job-1", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var job Job
+		if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if job.ID != "job-1" || job.Status != JobDone {
+			t.Fatalf("got job %+v, want id job-1 status done", job)
+		}
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleJob(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/", nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleJob(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/nope", nil))
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleJob(rec, httptest.NewRequest(http.MethodPost, "/api/v1/jobs/job-1", nil))
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHandleAsset(t *testing.T) {
+	s := NewSimulationService(map[string][]byte{"frame.bin": []byte("binary data")})
+
+	t.Run("happy path", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleAsset(rec, httptest.NewRequest(http.MethodGet, "/api/v1/assets/frame.bin", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if got := rec.Body.String(); got != "binary data" {
+			t.Fatalf("body = %q, want %q", got, "binary data")
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/octet-stream" {
+			t.Fatalf("Content-Type = %q, want application/octet-stream", ct)
+		}
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleAsset(rec, httptest.NewRequest(http.MethodGet, "/api/v1/assets/", nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleAsset(rec, httptest.NewRequest(http.MethodGet, "/api/v1/assets/nope.bin", nil))
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.handleAsset(rec, httptest.NewRequest(http.MethodPost, "/api/v1/assets/frame.bin", nil))
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
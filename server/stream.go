@@ -0,0 +1,96 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"golang.org/x/net/websocket"
+)
+
+// ControlMessage is a client-to-server message sent on the /ws/stream
+// control channel to pause, resume, or adjust the parameters of a running
+// job's stream.
+type ControlMessage struct {
+	Action string                 `json:"action"` // "pause", "resume", or "update"
+	JobID  string                 `json:"jobId"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// StreamService pushes frames from a running simulation job's Broker to
+// whichever clients are subscribed, over a websocket. It implements
+// RoutedService.
+type StreamService struct {
+	broker *Broker
+}
+
+// NewStreamService returns a StreamService that fans out frames from
+// broker.
+func NewStreamService(broker *Broker) *StreamService {
+	return &StreamService{broker: broker}
+}
+
+// AddRoutes registers the /ws/stream endpoint on mux.
+func (s *StreamService) AddRoutes(mux *http.ServeMux) {
+	mux.Handle("/ws/stream", websocket.Handler(s.handleStream))
+}
+
+func (s *StreamService) handleStream(ws *websocket.Conn) {
+	defer ws.Close()
+
+	var ctrl ControlMessage
+	if err := websocket.JSON.Receive(ws, &ctrl); err != nil {
+		log.Printf("stream: reading control message: %v", err)
+		return
+	}
+	if ctrl.JobID == "" {
+		log.Print("stream: control message missing jobId")
+		return
+	}
+
+	frames := s.broker.Subscribe(ctrl.JobID)
+	defer s.broker.Unsubscribe(ctrl.JobID, frames)
+
+	var paused atomic.Bool
+	done := make(chan struct{})
+	go s.readControl(ws, &paused, done)
+
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return
+			}
+			if paused.Load() {
+				continue
+			}
+			if err := websocket.Message.Send(ws, frame.Data); err != nil {
+				log.Printf("stream: sending frame: %v", err)
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// readControl processes further pause/resume/update messages on ws for
+// the lifetime of the connection, toggling *paused as instructed.
+func (s *StreamService) readControl(ws *websocket.Conn, paused *atomic.Bool, done chan struct{}) {
+	defer close(done)
+	for {
+		var ctrl ControlMessage
+		if err := websocket.JSON.Receive(ws, &ctrl); err != nil {
+			return
+		}
+		switch ctrl.Action {
+		case "pause":
+			paused.Store(true)
+		case "resume":
+			paused.Store(false)
+		case "update":
+			// TODO: thread ctrl.Params through to the owning simulation job
+			// once the worker pool exists.
+		}
+	}
+}
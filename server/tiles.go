@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TileGenerator rasterizes the PNG tile at the given zoom/x/y coordinate
+// of a lensing magnification map. It is called only on a cache miss.
+type TileGenerator func(z, x, y int) ([]byte, error)
+
+// TileService serves a Z/X/Y tile pyramid for large gravitational
+// lensing maps, rasterizing and caching tiles to disk on demand so pan
+// and zoom stay cheap after the first view. It implements RoutedService.
+type TileService struct {
+	mapDir   string
+	generate TileGenerator
+
+	renderMu sync.Mutex
+	pending  map[string]*tileLock
+}
+
+// tileLock serializes renders of a single tile path. refs counts the
+// goroutines currently holding or waiting on mu, so the entry can be
+// removed from TileService.pending as soon as no one needs it anymore,
+// rather than staying resident for every tile path ever served.
+type tileLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewTileService returns a TileService that caches tiles under mapDir,
+// generating missing ones with generate.
+func NewTileService(mapDir string, generate TileGenerator) *TileService {
+	return &TileService{
+		mapDir:   mapDir,
+		generate: generate,
+		pending:  make(map[string]*tileLock),
+	}
+}
+
+// AddRoutes registers the /map/{z}/{x}/{y}.png endpoint on mux.
+func (s *TileService) AddRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/map/", s.handleTile)
+}
+
+func (s *TileService) handleTile(w http.ResponseWriter, r *http.Request) {
+	z, x, y, err := parseTilePath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	path := s.tilePath(z, x, y)
+	if _, err := os.Stat(path); err != nil {
+		if err := s.renderTile(path, z, x, y); err != nil {
+			http.Error(w, fmt.Sprintf("rendering tile: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// renderTile generates and caches the tile at path, serializing concurrent
+// requests for the same tile so only one render runs and readers never
+// observe a partially-written file.
+func (s *TileService) renderTile(path string, z, x, y int) error {
+	lock := s.lockFor(path)
+	lock.mu.Lock()
+	defer func() {
+		lock.mu.Unlock()
+		s.unlockFor(path, lock)
+	}()
+
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	data, err := s.generate(z, x, y)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0o644)
+}
+
+// lockFor returns the tileLock serializing renders of the tile at path,
+// creating one on first use and marking this caller as a referent so
+// unlockFor won't remove it out from under a concurrent waiter.
+func (s *TileService) lockFor(path string) *tileLock {
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+	lock, ok := s.pending[path]
+	if !ok {
+		lock = &tileLock{}
+		s.pending[path] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+// unlockFor releases this caller's reference to path's tileLock, removing
+// it from pending once no one else is holding or waiting on it so steady-
+// state memory stays bounded by in-flight renders, not lifetime-distinct
+// tiles served.
+func (s *TileService) unlockFor(path string, lock *tileLock) {
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(s.pending, path)
+	}
+}
+
+// writeFileAtomic writes data to a temp file in path's directory and
+// renames it into place, so concurrent readers never see a torn write.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tile-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *TileService) tilePath(z, x, y int) string {
+	return filepath.Join(s.mapDir, strconv.Itoa(z), strconv.Itoa(x), fmt.Sprintf("%d.png", y))
+}
+
+// parseTilePath extracts the z/x/y coordinate from a "/map/{z}/{x}/{y}.png"
+// request path.
+func parseTilePath(urlPath string) (z, x, y int, err error) {
+	rest := strings.TrimPrefix(urlPath, "/map/")
+	rest = strings.TrimSuffix(rest, ".png")
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("malformed tile path %q", urlPath)
+	}
+
+	if z, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid z coordinate: %w", err)
+	}
+	if x, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	if y, err = strconv.Atoi(parts[2]); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+	return z, x, y, nil
+}
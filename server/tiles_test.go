@@ -0,0 +1,101 @@
+package server
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParseTilePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantZ   int
+		wantX   int
+		wantY   int
+		wantErr bool
+	}{
+		{name: "valid", path: "/map/3/5/7.png", wantZ: 3, wantX: 5, wantY: 7},
+		{name: "zero coordinates", path: "/map/0/0/0.png", wantZ: 0, wantX: 0, wantY: 0},
+		{name: "missing segment", path: "/map/3/5.png", wantErr: true},
+		{name: "extra segment", path: "/map/3/5/7/9.png", wantErr: true},
+		{name: "non-numeric z", path: "/map/a/5/7.png", wantErr: true},
+		{name: "non-numeric x", path: "/map/3/b/7.png", wantErr: true},
+		{name: "non-numeric y", path: "/map/3/5/c.png", wantErr: true},
+		{name: "missing extension still parses", path: "/map/3/5/7", wantZ: 3, wantX: 5, wantY: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, x, y, err := parseTilePath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseTilePath(%q) = %d/%d/%d, nil; want error", tt.path, z, x, y)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTilePath(%q) unexpected error: %v", tt.path, err)
+			}
+			if z != tt.wantZ || x != tt.wantX || y != tt.wantY {
+				t.Fatalf("parseTilePath(%q) = %d/%d/%d, want %d/%d/%d", tt.path, z, x, y, tt.wantZ, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+// TestTileServiceRenderTileDoesNotLeakLocks ensures that once a render
+// completes, its per-path tileLock is removed from pending rather than
+// staying resident for the lifetime of the server.
+func TestTileServiceRenderTileDoesNotLeakLocks(t *testing.T) {
+	var generated int
+	s := NewTileService(t.TempDir(), func(z, x, y int) ([]byte, error) {
+		generated++
+		return []byte("tile"), nil
+	})
+
+	path := s.tilePath(1, 2, 3)
+	if err := s.renderTile(path, 1, 2, 3); err != nil {
+		t.Fatalf("renderTile: %v", err)
+	}
+
+	if n := len(s.pending); n != 0 {
+		t.Fatalf("pending has %d entries after render completed, want 0", n)
+	}
+	if generated != 1 {
+		t.Fatalf("generate called %d times, want 1", generated)
+	}
+}
+
+// TestTileServiceRenderTileConcurrentMisses confirms concurrent requests
+// for the same not-yet-cached tile serialize on one render and that
+// pending is empty once every caller has finished.
+func TestTileServiceRenderTileConcurrentMisses(t *testing.T) {
+	var mu sync.Mutex
+	var generated int
+	s := NewTileService(t.TempDir(), func(z, x, y int) ([]byte, error) {
+		mu.Lock()
+		generated++
+		mu.Unlock()
+		return []byte("tile"), nil
+	})
+
+	path := s.tilePath(0, 0, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.renderTile(path, 0, 0, 0); err != nil {
+				t.Errorf("renderTile: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if generated != 1 {
+		t.Fatalf("generate called %d times across concurrent misses, want 1", generated)
+	}
+	if n := len(s.pending); n != 0 {
+		t.Fatalf("pending has %d entries after all renders completed, want 0", n)
+	}
+}